@@ -0,0 +1,215 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/olivere/elastic/v7/uritemplates"
+)
+
+// ClusterGetSettingsService allows to review cluster-wide settings. To
+// change them, use ClusterUpdateSettingsService instead.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/cluster-get-settings.html
+// for details.
+type ClusterGetSettingsService struct {
+	client *Client
+
+	pretty     *bool       // pretty format the returned JSON response
+	human      *bool       // return human readable values for statistics
+	errorTrace *bool       // include the stack trace of returned errors
+	filterPath []string    // list of filters used to reduce the response
+	headers    http.Header // custom request-level HTTP headers
+
+	masterTimeout   string
+	timeout         string
+	includeDefaults *bool
+	flatSettings    *bool
+}
+
+// NewClusterGetSettingsService returns a new ClusterGetSettingsService.
+func NewClusterGetSettingsService(client *Client) *ClusterGetSettingsService {
+	return &ClusterGetSettingsService{client: client}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *ClusterGetSettingsService) Pretty(pretty bool) *ClusterGetSettingsService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *ClusterGetSettingsService) Human(human bool) *ClusterGetSettingsService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *ClusterGetSettingsService) ErrorTrace(errorTrace bool) *ClusterGetSettingsService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *ClusterGetSettingsService) FilterPath(filterPath ...string) *ClusterGetSettingsService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Header adds a header to the request.
+func (s *ClusterGetSettingsService) Header(name string, value string) *ClusterGetSettingsService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Headers specifies the headers of the request.
+func (s *ClusterGetSettingsService) Headers(headers http.Header) *ClusterGetSettingsService {
+	s.headers = headers
+	return s
+}
+
+// MasterTimeout is documented as: Explicit operation timeout for connection to master node.
+func (s *ClusterGetSettingsService) MasterTimeout(masterTimeout string) *ClusterGetSettingsService {
+	s.masterTimeout = masterTimeout
+	return s
+}
+
+// Timeout is documented as: Explicit operation timeout.
+func (s *ClusterGetSettingsService) Timeout(timeout string) *ClusterGetSettingsService {
+	s.timeout = timeout
+	return s
+}
+
+// IncludeDefaults ensures that the settings which were not set explicitly are also returned.
+func (s *ClusterGetSettingsService) IncludeDefaults(includeDefaults bool) *ClusterGetSettingsService {
+	s.includeDefaults = &includeDefaults
+	return s
+}
+
+// FlatSettings is documented as: Return settings in flat format (default: false).
+func (s *ClusterGetSettingsService) FlatSettings(flatSettings bool) *ClusterGetSettingsService {
+	s.flatSettings = &flatSettings
+	return s
+}
+
+// Validate checks if the operation is valid.
+func (s *ClusterGetSettingsService) Validate() error {
+	return nil
+}
+
+// buildURL builds the URL for the operation.
+func (s *ClusterGetSettingsService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/_cluster/settings", map[string]string{})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if s.includeDefaults != nil {
+		params.Set("include_defaults", fmt.Sprintf("%v", *s.includeDefaults))
+	}
+	if s.flatSettings != nil {
+		params.Set("flat_settings", fmt.Sprintf("%v", *s.flatSettings))
+	}
+	if s.masterTimeout != "" {
+		params.Set("master_timeout", s.masterTimeout)
+	}
+	if s.timeout != "" {
+		params.Set("timeout", s.timeout)
+	}
+	return path, params, nil
+}
+
+// Do executes the operation.
+func (s *ClusterGetSettingsService) Do(ctx context.Context) (*ClusterGetSettingsResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the cluster-wide settings.
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "GET",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := new(ClusterGetSettingsResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// -- Result of a cluster get settings request.
+
+// ClusterGetSettingsResponse is the response of ClusterGetSettingsService.Do().
+type ClusterGetSettingsResponse struct {
+	Persistent map[string]interface{} `json:"persistent"`
+	Transient  map[string]interface{} `json:"transient"`
+	Defaults   map[string]interface{} `json:"defaults,omitempty"`
+}
+
+// Flatten collapses the nested Persistent, Transient and Defaults maps
+// into dotted keys, e.g. {"cluster":{"routing":{"allocation":{"enable":"all"}}}}
+// becomes {"cluster.routing.allocation.enable":"all"}. It is useful for
+// comparing the response against a request built with ClusterSettings
+// regardless of whether FlatSettings was set on the request.
+func (r *ClusterGetSettingsResponse) Flatten() (persistent, transient, defaults map[string]interface{}) {
+	persistent = flattenSettings("", r.Persistent, make(map[string]interface{}))
+	transient = flattenSettings("", r.Transient, make(map[string]interface{}))
+	defaults = flattenSettings("", r.Defaults, make(map[string]interface{}))
+	return persistent, transient, defaults
+}
+
+// flattenSettings recursively collapses a nested settings map into a
+// flat map with dotted keys.
+func flattenSettings(prefix string, settings map[string]interface{}, out map[string]interface{}) map[string]interface{} {
+	for k, v := range settings {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenSettings(key, nested, out)
+		} else {
+			out[key] = v
+		}
+	}
+	return out
+}
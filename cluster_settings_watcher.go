@@ -0,0 +1,264 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ClusterSettingsChangeEvent describes a single cluster setting that
+// changed between two polls of ClusterSettingsWatcher.
+type ClusterSettingsChangeEvent struct {
+	// Scope is either "persistent" or "transient".
+	Scope string
+	// Key is the dotted settings key, e.g. "cluster.routing.allocation.enable".
+	Key string
+	// OldValue is the previous value of the setting, or nil if it was unset.
+	OldValue interface{}
+	// NewValue is the current value of the setting, or nil if it was reset.
+	NewValue interface{}
+}
+
+// ClusterSettingsSink receives ClusterSettingsChangeEvent notifications
+// emitted by a ClusterSettingsWatcher. Implementations should return
+// quickly; slow sinks delay the delivery of events to other sinks and,
+// depending on the sink, may drop events.
+//
+// In-tree implementations are ClusterSettingsChannelSink, which
+// forwards events onto a Go channel, and ClusterSettingsWebhookSink,
+// which POSTs events to an HTTP endpoint. Users who want to forward
+// events to AMQP, Redis or Kafka can do so by implementing this
+// interface and registering it with ClusterSettingsWatcher.AddSink.
+type ClusterSettingsSink interface {
+	OnClusterSettingsChange(event ClusterSettingsChangeEvent) error
+}
+
+// -- Channel sink.
+
+// ClusterSettingsChannelSink is a ClusterSettingsSink that forwards
+// events onto a buffered Go channel. If the channel is full, the event
+// is dropped rather than blocking the watcher.
+type ClusterSettingsChannelSink struct {
+	C chan ClusterSettingsChangeEvent
+}
+
+// NewClusterSettingsChannelSink returns a ClusterSettingsChannelSink
+// whose channel has the given buffer size.
+func NewClusterSettingsChannelSink(buffer int) *ClusterSettingsChannelSink {
+	return &ClusterSettingsChannelSink{C: make(chan ClusterSettingsChangeEvent, buffer)}
+}
+
+// OnClusterSettingsChange implements ClusterSettingsSink.
+func (s *ClusterSettingsChannelSink) OnClusterSettingsChange(event ClusterSettingsChangeEvent) error {
+	select {
+	case s.C <- event:
+	default:
+		return fmt.Errorf("elastic: ClusterSettingsChannelSink channel is full, dropped event for %q", event.Key)
+	}
+	return nil
+}
+
+// -- HTTP webhook sink.
+
+// ClusterSettingsWebhookSink is a ClusterSettingsSink that POSTs each
+// event as JSON to a configured URL.
+type ClusterSettingsWebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewClusterSettingsWebhookSink returns a ClusterSettingsWebhookSink
+// that POSTs events to url using http.DefaultClient.
+func NewClusterSettingsWebhookSink(url string) *ClusterSettingsWebhookSink {
+	return &ClusterSettingsWebhookSink{URL: url, Client: http.DefaultClient}
+}
+
+// OnClusterSettingsChange implements ClusterSettingsSink.
+func (s *ClusterSettingsWebhookSink) OnClusterSettingsChange(event ClusterSettingsChangeEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("elastic: ClusterSettingsWebhookSink got HTTP status %d from %s", res.StatusCode, s.URL)
+	}
+	return nil
+}
+
+// -- Watcher.
+
+// ClusterSettingsWatcher polls ClusterGetSettingsService on an interval
+// and emits a ClusterSettingsChangeEvent to its registered sinks
+// whenever a persistent or transient cluster setting is added,
+// changed, or removed. It is safe to add sinks while Watch is running.
+//
+// Use it to react to (or audit) operational changes such as allocation
+// being disabled, watermark adjustments, or breaker limit changes,
+// without standing up a separate control plane.
+type ClusterSettingsWatcher struct {
+	client *Client
+
+	interval   time.Duration
+	maxBackoff time.Duration
+	onError    func(error)
+
+	mu    sync.Mutex
+	sinks []ClusterSettingsSink
+}
+
+// NewClusterSettingsWatcher returns a new ClusterSettingsWatcher that
+// polls every 30 seconds and backs off up to 5 minutes on error.
+func NewClusterSettingsWatcher(client *Client) *ClusterSettingsWatcher {
+	return &ClusterSettingsWatcher{
+		client:     client,
+		interval:   30 * time.Second,
+		maxBackoff: 5 * time.Minute,
+	}
+}
+
+// Interval sets how often the watcher polls the cluster settings.
+func (w *ClusterSettingsWatcher) Interval(interval time.Duration) *ClusterSettingsWatcher {
+	w.interval = interval
+	return w
+}
+
+// MaxBackoff sets the upper bound the watcher's poll interval backs off
+// to after consecutive errors. The backoff doubles after each error,
+// starting from Interval, and resets to Interval after a successful poll.
+func (w *ClusterSettingsWatcher) MaxBackoff(maxBackoff time.Duration) *ClusterSettingsWatcher {
+	w.maxBackoff = maxBackoff
+	return w
+}
+
+// OnError registers a callback invoked whenever a poll fails or a sink
+// returns an error. It is optional; errors are otherwise swallowed.
+func (w *ClusterSettingsWatcher) OnError(onError func(error)) *ClusterSettingsWatcher {
+	w.onError = onError
+	return w
+}
+
+// AddSink registers a sink to receive change events. This is the
+// extension point for external sinks (AMQP, Redis, Kafka, ...): wrap
+// them in a type that implements ClusterSettingsSink and register it
+// here, mirroring in-tree sinks like ClusterSettingsChannelSink and
+// ClusterSettingsWebhookSink.
+func (w *ClusterSettingsWatcher) AddSink(sink ClusterSettingsSink) *ClusterSettingsWatcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sinks = append(w.sinks, sink)
+	return w
+}
+
+// Watch polls the cluster settings until ctx is canceled, returning
+// ctx.Err() at that point. Each poll fetches the current persistent and
+// transient settings via ClusterGetSettingsService, diffs them against
+// the previous poll, and emits a ClusterSettingsChangeEvent per changed
+// key to every registered sink.
+func (w *ClusterSettingsWatcher) Watch(ctx context.Context) error {
+	var lastPersistent, lastTransient map[string]interface{}
+	backoff := w.interval
+
+	for {
+		persistent, transient, err := w.poll(ctx)
+		if err != nil {
+			w.reportError(err)
+			backoff = nextBackoff(backoff, w.maxBackoff)
+		} else {
+			if lastPersistent != nil || lastTransient != nil {
+				w.emitDiff("persistent", lastPersistent, persistent)
+				w.emitDiff("transient", lastTransient, transient)
+			}
+			lastPersistent, lastTransient = persistent, transient
+			backoff = w.interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// poll fetches and flattens the current persistent and transient
+// cluster settings.
+func (w *ClusterSettingsWatcher) poll(ctx context.Context) (persistent, transient map[string]interface{}, err error) {
+	res, err := NewClusterGetSettingsService(w.client).Do(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	persistent, transient, _ = res.Flatten()
+	return persistent, transient, nil
+}
+
+// emitDiff compares old and new flattened settings maps and emits a
+// change event for every key that was added, changed, or removed.
+func (w *ClusterSettingsWatcher) emitDiff(scope string, old, updated map[string]interface{}) {
+	seen := make(map[string]bool, len(old)+len(updated))
+	for key, newValue := range updated {
+		seen[key] = true
+		if oldValue, ok := old[key]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			w.emit(ClusterSettingsChangeEvent{Scope: scope, Key: key, OldValue: old[key], NewValue: newValue})
+		}
+	}
+	for key, oldValue := range old {
+		if seen[key] {
+			continue
+		}
+		w.emit(ClusterSettingsChangeEvent{Scope: scope, Key: key, OldValue: oldValue, NewValue: nil})
+	}
+}
+
+// emit delivers event to every registered sink, reporting any sink
+// error via onError.
+func (w *ClusterSettingsWatcher) emit(event ClusterSettingsChangeEvent) {
+	w.mu.Lock()
+	sinks := make([]ClusterSettingsSink, len(w.sinks))
+	copy(sinks, w.sinks)
+	w.mu.Unlock()
+
+	for _, sink := range sinks {
+		if err := sink.OnClusterSettingsChange(event); err != nil {
+			w.reportError(err)
+		}
+	}
+}
+
+// reportError invokes the OnError callback, if any.
+func (w *ClusterSettingsWatcher) reportError(err error) {
+	if w.onError != nil {
+		w.onError(err)
+	}
+}
+
+// nextBackoff doubles backoff, capped at max.
+func nextBackoff(backoff, max time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
@@ -14,7 +14,8 @@ import (
 	"github.com/olivere/elastic/v7/uritemplates"
 )
 
-// ClusterUpdateSettingsService allows to review and change cluster-wide settings.
+// ClusterUpdateSettingsService changes cluster-wide settings. To read
+// the currently applied settings, use ClusterGetSettingsService instead.
 //
 // See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/cluster-update-settings.html
 // for details.
@@ -27,10 +28,13 @@ type ClusterUpdateSettingsService struct {
 	filterPath []string    // list of filters used to reduce the response
 	headers    http.Header // custom request-level HTTP headers
 
-	includeDefaults *bool
-	flatSettings    *bool
-	bodyJson        interface{}
-	bodyString      string
+	masterTimeout string
+	timeout       string
+
+	flatSettings *bool
+	settings     *ClusterSettings
+	bodyJson     interface{}
+	bodyString   string
 }
 
 // NewClusterUpdateSettingsService returns a new ClusterUpdateSettingsService.
@@ -78,9 +82,15 @@ func (s *ClusterUpdateSettingsService) Headers(headers http.Header) *ClusterUpda
 	return s
 }
 
-// IncludeDefaults ensures that the settings which were not set explicitly are also returned.
-func (s *ClusterUpdateSettingsService) IncludeDefaults(includeDefaults bool) *ClusterUpdateSettingsService {
-	s.includeDefaults = &includeDefaults
+// MasterTimeout is documented as: Explicit operation timeout for connection to master node.
+func (s *ClusterUpdateSettingsService) MasterTimeout(masterTimeout string) *ClusterUpdateSettingsService {
+	s.masterTimeout = masterTimeout
+	return s
+}
+
+// Timeout is documented as: Explicit operation timeout.
+func (s *ClusterUpdateSettingsService) Timeout(timeout string) *ClusterUpdateSettingsService {
+	s.timeout = timeout
 	return s
 }
 
@@ -90,6 +100,17 @@ func (s *ClusterUpdateSettingsService) FlatSettings(flatSettings bool) *ClusterU
 	return s
 }
 
+// Settings specifies a typed ClusterSettings builder to use for the
+// persistent and transient blocks of the request body. It is merged
+// with a body set via BodyJson when that body is a map[string]interface{},
+// so BodyJson can still be used as an escape hatch for settings that
+// don't have a typed helper yet. A BodyJson body of any other type, or a
+// body set via BodyString, is sent as is and Settings is ignored.
+func (s *ClusterUpdateSettingsService) Settings(settings *ClusterSettings) *ClusterUpdateSettingsService {
+	s.settings = settings
+	return s
+}
+
 // Body specifies the configuration of the index as a string.
 // It is an alias for BodyString.
 func (s *ClusterUpdateSettingsService) Body(body string) *ClusterUpdateSettingsService {
@@ -140,15 +161,46 @@ func (s *ClusterUpdateSettingsService) buildURL() (string, url.Values, error) {
 	if len(s.filterPath) > 0 {
 		params.Set("filter_path", strings.Join(s.filterPath, ","))
 	}
-	if s.includeDefaults != nil {
-		params.Set("include_defaults", fmt.Sprintf("%v", *s.includeDefaults))
-	}
 	if s.flatSettings != nil {
 		params.Set("flat_settings", fmt.Sprintf("%v", *s.flatSettings))
 	}
+	if s.masterTimeout != "" {
+		params.Set("master_timeout", s.masterTimeout)
+	}
+	if s.timeout != "" {
+		params.Set("timeout", s.timeout)
+	}
 	return path, params, nil
 }
 
+// mergeSettings merges the persistent/transient blocks built via Settings
+// into body, which is typically the raw value passed to BodyJson. If body
+// is a map[string]interface{}, the settings are merged key by key so that
+// callers can combine typed helpers with raw escape-hatch settings. If
+// body isn't a map, it is returned unchanged and Settings is ignored.
+func (s *ClusterUpdateSettingsService) mergeSettings(body interface{}) interface{} {
+	if s.settings == nil {
+		return body
+	}
+	m, ok := body.(map[string]interface{})
+	if !ok {
+		return body
+	}
+	src := s.settings.source()
+	for k, v := range src {
+		if existing, ok := m[k].(map[string]interface{}); ok {
+			block, _ := v.(map[string]interface{})
+			for bk, bv := range block {
+				existing[bk] = bv
+			}
+			m[k] = existing
+		} else {
+			m[k] = v
+		}
+	}
+	return m
+}
+
 // Do executes the operation.
 func (s *ClusterUpdateSettingsService) Do(ctx context.Context) (*ClusterUpdateSettingsResponse, error) {
 	// Check pre-conditions
@@ -165,30 +217,21 @@ func (s *ClusterUpdateSettingsService) Do(ctx context.Context) (*ClusterUpdateSe
 	// Setup HTTP request body
 	var body interface{}
 	if s.bodyJson != nil {
-		body = s.bodyJson
-	} else {
+		body = s.mergeSettings(s.bodyJson)
+	} else if s.bodyString != "" {
 		body = s.bodyString
+	} else if s.settings != nil {
+		body = s.settings.source()
 	}
 
-	res := new(Response)
-	if body != nil {
-		// Update the cluster-wide settings.
-		res, err = s.client.PerformRequest(ctx, PerformRequestOptions{
-			Method:  "PUT",
-			Path:    path,
-			Params:  params,
-			Body:    body,
-			Headers: s.headers,
-		})
-	} else {
-		//  Get the cluster-wide settings as response
-		res, err = s.client.PerformRequest(ctx, PerformRequestOptions{
-			Method:  "GET",
-			Path:    path,
-			Params:  params,
-			Headers: s.headers,
-		})
-	}
+	// Update the cluster-wide settings.
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "PUT",
+		Path:    path,
+		Params:  params,
+		Body:    body,
+		Headers: s.headers,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -200,11 +243,121 @@ func (s *ClusterUpdateSettingsService) Do(ctx context.Context) (*ClusterUpdateSe
 	return ret, nil
 }
 
+// -- Typed builder for persistent/transient cluster settings.
+
+// ClusterSettings is a typed builder for the persistent and transient
+// blocks accepted by the Elasticsearch cluster settings API. Use
+// NewClusterSettings to create one, populate it with Persistent,
+// Transient and the well-known helpers below, then pass it to
+// ClusterUpdateSettingsService.Settings.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/cluster-update-settings.html
+// for details.
+type ClusterSettings struct {
+	persistent map[string]interface{}
+	transient  map[string]interface{}
+}
+
+// NewClusterSettings returns an empty ClusterSettings builder.
+func NewClusterSettings() *ClusterSettings {
+	return &ClusterSettings{
+		persistent: make(map[string]interface{}),
+		transient:  make(map[string]interface{}),
+	}
+}
+
+// Persistent sets a persistent cluster setting. Persistent settings
+// survive a full cluster restart.
+func (s *ClusterSettings) Persistent(key string, value interface{}) *ClusterSettings {
+	s.persistent[key] = value
+	return s
+}
+
+// Transient sets a transient cluster setting. Transient settings do
+// not survive a full cluster restart.
+func (s *ClusterSettings) Transient(key string, value interface{}) *ClusterSettings {
+	s.transient[key] = value
+	return s
+}
+
+// ResetPersistent clears a persistent cluster setting, reverting it to
+// its default. It is encoded as JSON null, as required by the
+// Elasticsearch cluster settings API.
+func (s *ClusterSettings) ResetPersistent(key string) *ClusterSettings {
+	s.persistent[key] = nil
+	return s
+}
+
+// ResetTransient clears a transient cluster setting, reverting it to
+// its default. It is encoded as JSON null, as required by the
+// Elasticsearch cluster settings API.
+func (s *ClusterSettings) ResetTransient(key string) *ClusterSettings {
+	s.transient[key] = nil
+	return s
+}
+
+// AllocationEnable sets cluster.routing.allocation.enable, one of
+// "all", "primaries", "new_primaries" or "none".
+func (s *ClusterSettings) AllocationEnable(mode string) *ClusterSettings {
+	return s.Persistent("cluster.routing.allocation.enable", mode)
+}
+
+// RebalanceEnable sets cluster.routing.rebalance.enable, one of
+// "all", "primaries", "replicas" or "none".
+func (s *ClusterSettings) RebalanceEnable(mode string) *ClusterSettings {
+	return s.Persistent("cluster.routing.rebalance.enable", mode)
+}
+
+// DiskWatermarkLow sets cluster.routing.allocation.disk.watermark.low,
+// e.g. "85%" or "500mb".
+func (s *ClusterSettings) DiskWatermarkLow(value string) *ClusterSettings {
+	return s.Persistent("cluster.routing.allocation.disk.watermark.low", value)
+}
+
+// DiskWatermarkHigh sets cluster.routing.allocation.disk.watermark.high,
+// e.g. "90%" or "200mb".
+func (s *ClusterSettings) DiskWatermarkHigh(value string) *ClusterSettings {
+	return s.Persistent("cluster.routing.allocation.disk.watermark.high", value)
+}
+
+// DiskWatermarkFloodStage sets
+// cluster.routing.allocation.disk.watermark.flood_stage, e.g. "95%" or
+// "100mb".
+func (s *ClusterSettings) DiskWatermarkFloodStage(value string) *ClusterSettings {
+	return s.Persistent("cluster.routing.allocation.disk.watermark.flood_stage", value)
+}
+
+// ClusterMaxShardsPerNode sets cluster.max_shards_per_node.
+func (s *ClusterSettings) ClusterMaxShardsPerNode(n int) *ClusterSettings {
+	return s.Persistent("cluster.max_shards_per_node", n)
+}
+
+// BreakerFielddataLimit sets indices.breaker.fielddata.limit, e.g. "40%".
+func (s *ClusterSettings) BreakerFielddataLimit(value string) *ClusterSettings {
+	return s.Persistent("indices.breaker.fielddata.limit", value)
+}
+
+// source returns the settings as a map with "persistent" and
+// "transient" keys, ready to be merged into a request body or
+// marshaled directly.
+func (s *ClusterSettings) source() map[string]interface{} {
+	source := make(map[string]interface{})
+	if len(s.persistent) > 0 {
+		source["persistent"] = s.persistent
+	}
+	if len(s.transient) > 0 {
+		source["transient"] = s.transient
+	}
+	return source
+}
+
 // -- Result of a create index request.
 
 // ClusterUpdateSettingsResponse is the response of ClusterUpdateSettingsService.Do().
 type ClusterUpdateSettingsResponse struct {
-	Acknowledged       bool   `json:"acknowledged"`
-	ShardsAcknowledged bool   `json:"shards_acknowledged"`
-	Index              string `json:"index,omitempty"`
+	Acknowledged       bool                   `json:"acknowledged"`
+	ShardsAcknowledged bool                   `json:"shards_acknowledged"`
+	Index              string                 `json:"index,omitempty"`
+	Persistent         map[string]interface{} `json:"persistent"`
+	Transient          map[string]interface{} `json:"transient"`
 }